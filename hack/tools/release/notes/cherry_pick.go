@@ -0,0 +1,231 @@
+//go:build tools
+// +build tools
+
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// cherryPickTitleRE matches a cherry-pick PR title such as
+// "Cherry pick of #1234 onto release-1.6" or "[release-1.6] Cherry pick #1234".
+var cherryPickTitleRE = regexp.MustCompile(`(?i)cherry[- ]?pick(?:\s+of)?\s+#(\d+)`)
+
+// cherryPickTrailerRE matches the trailer `git cherry-pick -x` appends to a
+// commit message, e.g. "(cherry picked from commit abc1234...)".
+var cherryPickTrailerRE = regexp.MustCompile(`\(cherry picked from commit ([0-9a-f]{7,40})\)`)
+
+// releaseBranchTitlePrefixRE matches the "[release-X.Y] " prefix automation
+// adds to PRs backported onto a release branch, whether or not the title
+// also names the original PR number.
+var releaseBranchTitlePrefixRE = regexp.MustCompile(`^\[release-[\w.]+\]\s*`)
+
+// cherryPickNormalizer wraps a PRLister and, for every PR that looks like a
+// cherry-pick onto a release branch, swaps in the original PR's title,
+// labels and author from sourceBranch so it renders with the same metadata
+// it would have had if it were never backported. Cherry-picks and their
+// originals are de-duplicated down to a single entry per PR number, and any
+// PR already described in priorReleaseRef's release notes is dropped so
+// patch releases don't repeat earlier entries.
+type cherryPickNormalizer struct {
+	inner           PRLister
+	repo            string
+	sourceBranch    string
+	token           string
+	priorReleaseRef string
+	getPR           func(repo string, number int, token string) (PullRequest, error)
+}
+
+func newCherryPickNormalizer(inner PRLister, repo, sourceBranch, token, priorReleaseRef string) *cherryPickNormalizer {
+	return &cherryPickNormalizer{
+		inner:           inner,
+		repo:            repo,
+		sourceBranch:    sourceBranch,
+		token:           token,
+		priorReleaseRef: priorReleaseRef,
+		getPR:           getPRFromGithubAPI,
+	}
+}
+
+func (n *cherryPickNormalizer) listPRs() ([]PullRequest, error) {
+	prs, err := n.inner.listPRs()
+	if err != nil {
+		return nil, err
+	}
+
+	alreadyReleased, err := n.alreadyReleasedNumbers()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute already-released PR numbers")
+	}
+
+	seen := make(map[int]bool, len(prs))
+	normalized := make([]PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		resolved, err := n.resolveOriginal(pr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to resolve original PR for cherry-pick #%d", pr.Number)
+		}
+
+		if seen[resolved.Number] || alreadyReleased[resolved.Number] {
+			continue
+		}
+		seen[resolved.Number] = true
+		normalized = append(normalized, resolved)
+	}
+
+	return normalized, nil
+}
+
+// alreadyReleasedNumbers returns the PR numbers already described by notes
+// published up to priorReleaseRef (typically the previous patch tag on the
+// same release branch), so this run doesn't repeat them.
+func (n *cherryPickNormalizer) alreadyReleasedNumbers() (map[int]bool, error) {
+	released := make(map[int]bool)
+	if n.priorReleaseRef == "" {
+		return released, nil
+	}
+
+	numbers, err := prNumbersFromCommitRange(n.priorReleaseRef)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, number := range numbers {
+		released[number] = true
+	}
+
+	return released, nil
+}
+
+// resolveOriginal returns the PullRequest to render for pr: either pr
+// itself, or the original PR it cherry-picks, fetched from n.sourceBranch.
+func (n *cherryPickNormalizer) resolveOriginal(pr PullRequest) (PullRequest, error) {
+	number, hasNumber, isCherryPick, err := n.detectCherryPick(pr)
+	if err != nil {
+		return PullRequest{}, err
+	}
+	if !isCherryPick {
+		return pr, nil
+	}
+
+	if !hasNumber || n.token == "" {
+		// We know it's a cherry-pick but can't resolve the original PR (no
+		// token, or the title/trailer didn't carry enough information) - at
+		// least strip the bot-added title prefix so it doesn't leak into
+		// the rendered notes.
+		pr.Title = releaseBranchTitlePrefixRE.ReplaceAllString(pr.Title, "")
+		return pr, nil
+	}
+
+	original, err := n.getPR(n.repo, number, n.token)
+	if err != nil {
+		return PullRequest{}, err
+	}
+
+	if original.TargetBranch != n.sourceBranch {
+		// The referenced PR wasn't merged into sourceBranch, so it isn't the
+		// upstream original; keep the cherry-pick with its prefix stripped.
+		pr.Title = releaseBranchTitlePrefixRE.ReplaceAllString(pr.Title, "")
+		return pr, nil
+	}
+
+	return original, nil
+}
+
+// detectCherryPick reports whether pr is a cherry-pick onto a release
+// branch and, if it can be determined, the number of the original PR it
+// backports. A PR can be recognized as a cherry-pick (isCherryPick=true)
+// even when the original PR number can't be resolved, e.g. a title that
+// only carries the "[release-X.Y]" prefix with no PR reference.
+func (n *cherryPickNormalizer) detectCherryPick(pr PullRequest) (number int, hasNumber bool, isCherryPick bool, err error) {
+	if matches := cherryPickTitleRE.FindStringSubmatch(pr.Title); matches != nil {
+		if number, err := strconv.Atoi(matches[1]); err == nil {
+			return number, true, true, nil
+		}
+	}
+
+	if matches := cherryPickTrailerRE.FindStringSubmatch(pr.Body); matches != nil {
+		if n.token == "" {
+			return 0, false, true, nil
+		}
+
+		number, err := findPRForCommit(n.repo, matches[1], n.token)
+		if err != nil {
+			// We know it cherry-picked *something*, we just couldn't map
+			// the commit SHA back to a PR number.
+			return 0, false, true, nil
+		}
+
+		return number, true, true, nil
+	}
+
+	if releaseBranchTitlePrefixRE.MatchString(pr.Title) {
+		return 0, false, true, nil
+	}
+
+	return 0, false, false, nil
+}
+
+type githubCommitSearchResult struct {
+	Items []struct {
+		Number int `json:"number"`
+	} `json:"items"`
+}
+
+// findPRForCommit resolves the PR that introduced a commit SHA via the
+// GitHub search API, for cherry-picks whose title doesn't reference the
+// original PR number but whose commit message carries a
+// "(cherry picked from commit SHA)" trailer.
+func findPRForCommit(repo, sha, token string) (int, error) {
+	url := fmt.Sprintf("https://api.github.com/search/issues?q=repo:%s+type:pr+hash:%s", repo, sha)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, errors.Errorf("unexpected status %d searching for commit %s", resp.StatusCode, sha)
+	}
+
+	var result githubCommitSearchResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, err
+	}
+
+	if len(result.Items) == 0 {
+		return 0, errors.Errorf("no PR found for commit %s", sha)
+	}
+
+	return result.Items[0].Number, nil
+}
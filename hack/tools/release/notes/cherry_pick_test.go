@@ -0,0 +1,117 @@
+//go:build tools
+// +build tools
+
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import "testing"
+
+func TestDetectCherryPick(t *testing.T) {
+	tests := []struct {
+		name             string
+		pr               PullRequest
+		token            string
+		wantNumber       int
+		wantHasNumber    bool
+		wantIsCherryPick bool
+	}{
+		{
+			name:             "title names the original PR",
+			pr:               PullRequest{Title: "Cherry pick of #1234 onto release-1.6"},
+			wantNumber:       1234,
+			wantHasNumber:    true,
+			wantIsCherryPick: true,
+		},
+		{
+			name:             "release branch prefix with no PR reference is still detected",
+			pr:               PullRequest{Title: "[release-1.6] Fix the foo bug"},
+			wantHasNumber:    false,
+			wantIsCherryPick: true,
+		},
+		{
+			name:             "cherry-pick trailer without a token cannot be resolved but is detected",
+			pr:               PullRequest{Title: "Fix the foo bug", Body: "(cherry picked from commit abc1234def5678)"},
+			wantHasNumber:    false,
+			wantIsCherryPick: true,
+		},
+		{
+			name:             "ordinary PR is not a cherry-pick",
+			pr:               PullRequest{Title: "Fix the foo bug"},
+			wantIsCherryPick: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := &cherryPickNormalizer{token: tt.token}
+			number, hasNumber, isCherryPick, err := n.detectCherryPick(tt.pr)
+			if err != nil {
+				t.Fatalf("detectCherryPick() returned error: %v", err)
+			}
+			if isCherryPick != tt.wantIsCherryPick {
+				t.Errorf("isCherryPick = %v, want %v", isCherryPick, tt.wantIsCherryPick)
+			}
+			if hasNumber != tt.wantHasNumber {
+				t.Errorf("hasNumber = %v, want %v", hasNumber, tt.wantHasNumber)
+			}
+			if hasNumber && number != tt.wantNumber {
+				t.Errorf("number = %d, want %d", number, tt.wantNumber)
+			}
+		})
+	}
+}
+
+func TestCherryPickNormalizerKeepsAllPRsWithoutPriorRelease(t *testing.T) {
+	inner := &fakePRLister{
+		prs: []PullRequest{
+			{Number: 100, Title: "Fix the foo bug"},
+			{Number: 200, Title: "Fix the bar bug"},
+		},
+	}
+
+	n := newCherryPickNormalizer(inner, "org/repo", "main", "", "")
+	n.getPR = func(repo string, number int, token string) (PullRequest, error) {
+		t.Fatalf("getPR should not be called without a token")
+		return PullRequest{}, nil
+	}
+
+	released, err := n.alreadyReleasedNumbers()
+	if err != nil {
+		t.Fatalf("alreadyReleasedNumbers() returned error: %v", err)
+	}
+	if len(released) != 0 {
+		t.Fatalf("expected no already-released numbers when priorReleaseRef is empty, got %v", released)
+	}
+
+	prs, err := n.listPRs()
+	if err != nil {
+		t.Fatalf("listPRs() returned error: %v", err)
+	}
+	if len(prs) != 2 {
+		t.Fatalf("expected 2 PRs, got %d", len(prs))
+	}
+}
+
+type fakePRLister struct {
+	prs []PullRequest
+	err error
+}
+
+func (f *fakePRLister) listPRs() ([]PullRequest, error) {
+	return f.prs, f.err
+}
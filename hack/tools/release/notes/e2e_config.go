@@ -0,0 +1,167 @@
+//go:build tools
+// +build tools
+
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/cluster-api/test/framework/kubernetesversions"
+)
+
+// e2eConfig is the subset of a clusterctl e2e config (test/e2e/config/*.yaml)
+// the notes generator needs: the KUBERNETES_VERSION* variables that record
+// which Kubernetes versions are exercised by the e2e suite.
+type e2eConfig struct {
+	Variables map[string]string `json:"variables"`
+}
+
+// envVariableRE matches the docker-compose-style "${VAR}" and "${VAR:-default}"
+// expressions clusterctl e2e config files use to pull variables from the
+// environment a test run was invoked with.
+var envVariableRE = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// loadE2EConfig reads a clusterctl e2e config file from disk and expands any
+// "${VAR}"/"${VAR:-default}" expressions in its variables against the
+// current environment, the same substitution clusterctl's e2e framework
+// applies when it loads the config for a test run. Without this, variables
+// that are left as template expressions in the checked-in file (rather than
+// a literal version) would be resolved verbatim instead of to the version
+// the environment actually requests.
+func loadE2EConfig(path string) (*e2eConfig, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // the path is an operator-supplied flag, not user input.
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read e2e config %q", path)
+	}
+
+	config := &e2eConfig{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse e2e config %q", path)
+	}
+
+	for name, value := range config.Variables {
+		config.Variables[name] = expandEnvVariables(value)
+	}
+
+	return config, nil
+}
+
+// expandEnvVariables resolves "${VAR}" and "${VAR:-default}" expressions in
+// value against the current environment, falling back to the default (or
+// the empty string if there isn't one) when the variable isn't set.
+func expandEnvVariables(value string) string {
+	return envVariableRE.ReplaceAllStringFunc(value, func(expr string) string {
+		match := envVariableRE.FindStringSubmatch(expr)
+		name, def := match[1], match[3]
+		if resolved, ok := os.LookupEnv(name); ok {
+			return resolved
+		}
+
+		return def
+	})
+}
+
+// kubernetesSupportMatrix is the resolved set of Kubernetes versions a
+// release is tested against, rendered into the release notes header.
+type kubernetesSupportMatrix struct {
+	ManagementCluster  string `json:"managementCluster" yaml:"managementCluster"`
+	WorkloadClusterMin string `json:"workloadClusterMin" yaml:"workloadClusterMin"`
+	WorkloadClusterMax string `json:"workloadClusterMax" yaml:"workloadClusterMax"`
+	TestedCIVersion    string `json:"testedCiVersion" yaml:"testedCiVersion"`
+}
+
+// needsVersionResolution reports whether value is a marker that still needs
+// resolving to a concrete vX.Y.Z version, rather than a literal version
+// already. This covers every marker kubernetesversions.ResolveVersion
+// understands ("ci/latest-1.30", "stable-1.30"), plus any "${" text that
+// expandEnvVariables could not expand because it isn't a well-formed
+// expression - printing that verbatim would silently corrupt the support
+// matrix. A variable that expands to the empty string is not resolved.
+func needsVersionResolution(value string) bool {
+	return strings.HasPrefix(value, "ci/") || strings.HasPrefix(value, "stable-") || strings.Contains(value, "${")
+}
+
+// resolveKubernetesSupportMatrix extracts the KUBERNETES_VERSION* variables
+// from an e2e config and resolves any CI or stable-channel markers (e.g.
+// "ci/latest-1.30", "stable-1.30") to concrete vX.Y.Z versions via
+// kubernetesversions.ResolveVersion, so the support matrix in the release
+// notes is reproducible rather than hand-maintained.
+func resolveKubernetesSupportMatrix(ctx context.Context, config *e2eConfig) (*kubernetesSupportMatrix, error) {
+	resolve := func(name string) (string, error) {
+		value, ok := config.Variables[name]
+		if !ok || value == "" {
+			return "", nil
+		}
+
+		if !needsVersionResolution(value) {
+			return value, nil
+		}
+
+		resolved, err := kubernetesversions.ResolveVersion(ctx, value)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to resolve %s=%q", name, value)
+		}
+
+		return resolved, nil
+	}
+
+	management, err := resolve("KUBERNETES_VERSION")
+	if err != nil {
+		return nil, err
+	}
+
+	workloadMin, err := resolve("KUBERNETES_VERSION_UPGRADE_FROM")
+	if err != nil {
+		return nil, err
+	}
+
+	workloadMax, err := resolve("KUBERNETES_VERSION_UPGRADE_TO")
+	if err != nil {
+		return nil, err
+	}
+
+	testedCI, err := resolve("KUBERNETES_VERSION_LATEST_CI")
+	if err != nil {
+		return nil, err
+	}
+
+	return &kubernetesSupportMatrix{
+		ManagementCluster:  management,
+		WorkloadClusterMin: workloadMin,
+		WorkloadClusterMax: workloadMax,
+		TestedCIVersion:    testedCI,
+	}, nil
+}
+
+// loadKubernetesSupportMatrix loads a clusterctl e2e config and resolves its
+// Kubernetes version support matrix in one step.
+func loadKubernetesSupportMatrix(e2eConfigPath string) (*kubernetesSupportMatrix, error) {
+	config, err := loadE2EConfig(e2eConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveKubernetesSupportMatrix(context.Background(), config)
+}
@@ -0,0 +1,52 @@
+//go:build tools
+// +build tools
+
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import "github.com/pkg/errors"
+
+// notesGenerator wires a PRLister, a PREntryProcessor and a
+// notesPrinter together into a single run.
+type notesGenerator struct {
+	lister    PRLister
+	processor PREntryProcessor
+	printer   notesPrinter
+}
+
+func newNotesGenerator(lister PRLister, processor PREntryProcessor, printer notesPrinter) *notesGenerator {
+	return &notesGenerator{
+		lister:    lister,
+		processor: processor,
+		printer:   printer,
+	}
+}
+
+func (g *notesGenerator) run() error {
+	prs, err := g.lister.listPRs()
+	if err != nil {
+		return errors.Wrap(err, "failed to list PRs")
+	}
+
+	entries := make([]PREntry, 0, len(prs))
+	for _, pr := range prs {
+		entries = append(entries, g.processor.process(pr))
+	}
+
+	return g.printer.print(entries)
+}
@@ -0,0 +1,179 @@
+//go:build tools
+// +build tools
+
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// squashMergePRNumberRE matches the PR number GitHub/tide embed at the end of
+// a squash-merge commit subject, e.g. "Fix the foo bug (#1234)". cluster-api
+// and the rest of kubernetes-sigs are squash-merged, so commits in the range
+// have no second parent and never show up in `git log --merges`.
+var squashMergePRNumberRE = regexp.MustCompile(`\(#(\d+)\)\s*$`)
+
+// gitAPIPRLister lists merged PRs between two refs without requiring the gh
+// CLI: it walks the commits between from and to in a local git clone, takes
+// each PR number from a squash-merge commit subject, and resolves the PR's
+// title, author and labels through the GitHub REST API using GITHUB_TOKEN.
+type gitAPIPRLister struct {
+	repo  string
+	from  ref
+	to    ref
+	token string
+	getPR func(repo string, number int, token string) (PullRequest, error)
+}
+
+func newGitAPIPRLister(repo string, from, to ref) *gitAPIPRLister {
+	return &gitAPIPRLister{
+		repo:  repo,
+		from:  from,
+		to:    to,
+		token: os.Getenv("GITHUB_TOKEN"),
+		getPR: getPRFromGithubAPI,
+	}
+}
+
+func (l *gitAPIPRLister) listPRs() ([]PullRequest, error) {
+	if l.token == "" {
+		return nil, errors.New("GITHUB_TOKEN must be set to use the git+api lister")
+	}
+
+	numbers, err := prNumbersFromCommitRange(l.from.value + ".." + l.to.value)
+	if err != nil {
+		return nil, err
+	}
+
+	prs := make([]PullRequest, 0, len(numbers))
+	for _, number := range numbers {
+		pr, err := l.getPR(l.repo, number, l.token)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch PR #%d", number)
+		}
+		prs = append(prs, pr)
+	}
+
+	return prs, nil
+}
+
+// prNumbersFromCommitRange walks every commit in rev (e.g. "v1.5.0..v1.6.0",
+// or just a single rev to walk all of its ancestors) and extracts the PR
+// number from each commit subject that matches the squash-merge convention.
+func prNumbersFromCommitRange(rev string) ([]int, error) {
+	cmd := exec.Command("git", "log", "--pretty=format:%s", rev)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "failed to walk commits %q: %s", rev, stderr.String())
+	}
+
+	return parsePRNumbersFromCommitSubjects(stdout.String()), nil
+}
+
+// parsePRNumbersFromCommitSubjects extracts every squash-merge PR number
+// from a newline-separated list of commit subjects.
+func parsePRNumbersFromCommitSubjects(subjects string) []int {
+	var numbers []int
+	for _, line := range strings.Split(subjects, "\n") {
+		matches := squashMergePRNumberRE.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+
+		number, err := strconv.Atoi(matches[1])
+		if err != nil {
+			continue
+		}
+
+		numbers = append(numbers, number)
+	}
+
+	return numbers
+}
+
+type githubAPIPullRequest struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	User   struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	MergeCommitSHA string `json:"merge_commit_sha"`
+	Base           struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+// getPRFromGithubAPI fetches a single PR's metadata from the GitHub REST API.
+func getPRFromGithubAPI(repo string, number int, token string) (PullRequest, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/pulls/%d", repo, number)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return PullRequest{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return PullRequest{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return PullRequest{}, errors.Errorf("unexpected status %d fetching PR #%d", resp.StatusCode, number)
+	}
+
+	var raw githubAPIPullRequest
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return PullRequest{}, err
+	}
+
+	labels := make([]string, 0, len(raw.Labels))
+	for _, label := range raw.Labels {
+		labels = append(labels, label.Name)
+	}
+
+	return PullRequest{
+		Number:         raw.Number,
+		Title:          raw.Title,
+		Body:           raw.Body,
+		Author:         raw.User.Login,
+		Labels:         labels,
+		MergeCommitSHA: raw.MergeCommitSHA,
+		TargetBranch:   raw.Base.Ref,
+	}, nil
+}
@@ -0,0 +1,113 @@
+//go:build tools
+// +build tools
+
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import "strings"
+
+// prKind classifies a PR by the kind of change it contains, mirroring the
+// taxonomy used by kubebuilder-release-tools' notes composer.
+type prKind int
+
+const (
+	prKindOther prKind = iota
+	prKindInfra
+	prKindDocumentation
+	prKindBugFix
+	prKindFeature
+	prKindBreaking
+)
+
+// prKindByIncreasingSeverity lists every kind from least to most severe, so
+// the highest semver bump can be found by taking the max over a release.
+var prKindByIncreasingSeverity = []prKind{
+	prKindOther,
+	prKindInfra,
+	prKindDocumentation,
+	prKindBugFix,
+	prKindFeature,
+	prKindBreaking,
+}
+
+func (k prKind) sectionTitle() string {
+	switch k {
+	case prKindBreaking:
+		return "Breaking Changes"
+	case prKindFeature:
+		return "New Features"
+	case prKindBugFix:
+		return "Bug Fixes"
+	case prKindDocumentation:
+		return "Documentation"
+	case prKindInfra:
+		return "Others"
+	default:
+		return "Others"
+	}
+}
+
+// semverBump is the release bump implied by the highest-severity prKind
+// present in a range of PRs.
+type semverBump string
+
+const (
+	semverBumpMajor semverBump = "major"
+	semverBumpMinor semverBump = "minor"
+	semverBumpPatch semverBump = "patch"
+)
+
+func (k prKind) semverBump() semverBump {
+	switch k {
+	case prKindBreaking:
+		return semverBumpMajor
+	case prKindFeature:
+		return semverBumpMinor
+	default:
+		return semverBumpPatch
+	}
+}
+
+// prefixSet pairs the two conventional title prefixes, emoji and text, used
+// to mark a PR's kind, e.g. "⚠️ Drop support for..." or ":warning: Drop support for...".
+type prefixSet struct {
+	emoji string
+	text  string
+	kind  prKind
+}
+
+var prefixes = []prefixSet{
+	{emoji: "⚠️", text: ":warning:", kind: prKindBreaking},
+	{emoji: "✨", text: ":sparkles:", kind: prKindFeature},
+	{emoji: "🐛", text: ":bug:", kind: prKindBugFix},
+	{emoji: "📖", text: ":book:", kind: prKindDocumentation},
+	{emoji: "🌱", text: ":seedling:", kind: prKindInfra},
+}
+
+// emojiKind returns the prKind encoded by a PR title's leading emoji or
+// `:shortcode:` prefix, and whether a recognized prefix was found at all.
+func emojiKind(title string) (prKind, bool) {
+	trimmed := strings.TrimSpace(title)
+	for _, p := range prefixes {
+		if strings.HasPrefix(trimmed, p.emoji) || strings.HasPrefix(trimmed, p.text) {
+			return p.kind, true
+		}
+	}
+
+	return prKindOther, false
+}
@@ -0,0 +1,88 @@
+//go:build tools
+// +build tools
+
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import "testing"
+
+func TestEmojiKind(t *testing.T) {
+	tests := []struct {
+		name      string
+		title     string
+		wantKind  prKind
+		wantFound bool
+	}{
+		{name: "emoji prefix", title: "⚠️ Drop support for v1alpha3", wantKind: prKindBreaking, wantFound: true},
+		{name: "shortcode prefix", title: ":sparkles: Add support for foo", wantKind: prKindFeature, wantFound: true},
+		{name: "leading whitespace is trimmed", title: "  🐛 Fix the foo bug", wantKind: prKindBugFix, wantFound: true},
+		{name: "no recognized prefix", title: "Fix the foo bug", wantKind: prKindOther, wantFound: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, found := emojiKind(tt.title)
+			if kind != tt.wantKind {
+				t.Errorf("emojiKind() kind = %v, want %v", kind, tt.wantKind)
+			}
+			if found != tt.wantFound {
+				t.Errorf("emojiKind() found = %v, want %v", found, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestPrKindSemverBump(t *testing.T) {
+	tests := []struct {
+		kind prKind
+		want semverBump
+	}{
+		{kind: prKindBreaking, want: semverBumpMajor},
+		{kind: prKindFeature, want: semverBumpMinor},
+		{kind: prKindBugFix, want: semverBumpPatch},
+		{kind: prKindDocumentation, want: semverBumpPatch},
+		{kind: prKindInfra, want: semverBumpPatch},
+		{kind: prKindOther, want: semverBumpPatch},
+	}
+
+	for _, tt := range tests {
+		if got := tt.kind.semverBump(); got != tt.want {
+			t.Errorf("prKind(%d).semverBump() = %v, want %v", tt.kind, got, tt.want)
+		}
+	}
+}
+
+func TestPrKindSectionTitle(t *testing.T) {
+	tests := []struct {
+		kind prKind
+		want string
+	}{
+		{kind: prKindBreaking, want: "Breaking Changes"},
+		{kind: prKindFeature, want: "New Features"},
+		{kind: prKindBugFix, want: "Bug Fixes"},
+		{kind: prKindDocumentation, want: "Documentation"},
+		{kind: prKindInfra, want: "Others"},
+		{kind: prKindOther, want: "Others"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.kind.sectionTitle(); got != tt.want {
+			t.Errorf("prKind(%d).sectionTitle() = %q, want %q", tt.kind, got, tt.want)
+		}
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"os"
 	"os/exec"
 
 	"github.com/blang/semver/v4"
@@ -53,6 +54,11 @@ type notesCmdConfig struct {
 	preReleaseVersion           bool
 	deprecation                 bool
 	addKubernetesVersionSupport bool
+	taxonomy                    string
+	lister                      string
+	format                      string
+	cherryPickSourceBranch      string
+	e2eConfigPath               string
 }
 
 func readCmdConfig() *notesCmdConfig {
@@ -69,6 +75,15 @@ func readCmdConfig() *notesCmdConfig {
 	flag.BoolVar(&config.deprecation, "deprecation", true, "If enabled, will add a templated deprecation warning header.")
 	flag.BoolVar(&config.addKubernetesVersionSupport, "add-kubernetes-version-support", true, "If enabled, will add the Kubernetes version support header.")
 
+	flag.StringVar(&config.taxonomy, "taxonomy", "emoji", "The PR title taxonomy to classify PRs with: emoji, area-label or both. "+
+		"area-label only distinguishes docs and infra/CI area labels from everything else - it has no signal for breaking changes, "+
+		"features or bugfixes, so most PRs land in Others with a patch-level semver bump. It is not an equivalent alternative to "+
+		"emoji for semver-bump purposes; prefer both, which falls back to area-label only to flag docs/infra PRs emoji missed.")
+	flag.StringVar(&config.lister, "lister", "auto", "The PR lister to use: gh, git+api, or auto to pick gh if installed and git+api otherwise.")
+	flag.StringVar(&config.format, "format", "markdown", "The output format to render release notes in: markdown, json or yaml.")
+	flag.StringVar(&config.cherryPickSourceBranch, "cherry-pick-source-branch", "main", "The upstream branch to normalize cherry-picked PRs against when generating notes for a release branch.")
+	flag.StringVar(&config.e2eConfigPath, "e2e-config", "", "Path to a clusterctl e2e config file (test/e2e/config/*.yaml) to resolve the Kubernetes version support matrix from. If unset, the support header falls back to a hand-maintained placeholder.")
+
 	flag.Parse()
 
 	return config
@@ -94,32 +109,82 @@ func (cmd *notesCmd) run() error {
 		return err
 	}
 
-	if err := ensureInstalledDependencies(); err != nil {
+	resolvedLister, err := resolveLister(cmd.config.lister)
+	if err != nil {
 		return err
 	}
 
 	from, to := parseRef(cmd.config.fromRef), parseRef(cmd.config.toRef)
 
-	printer := newReleaseNotesPrinter(cmd.config.repo, from.value)
-	printer.isPreRelease = cmd.config.preReleaseVersion
-	printer.printDeprecation = cmd.config.deprecation
-	printer.printKubernetesSupport = cmd.config.addKubernetesVersionSupport
+	var kubernetesSupport *kubernetesSupportMatrix
+	if cmd.config.addKubernetesVersionSupport && cmd.config.e2eConfigPath != "" {
+		kubernetesSupport, err = loadKubernetesSupportMatrix(cmd.config.e2eConfigPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	var deprecationNotice string
+	if cmd.config.deprecation {
+		deprecationNotice = deprecationNoticeText
+	}
+
+	var printer notesPrinter
+	switch outputFormat(cmd.config.format) {
+	case outputFormatJSON, outputFormatYAML:
+		docPrinter := newStructuredPrinter(cmd.config.repo, from.value, to.value, cmd.config.newTag, outputFormat(cmd.config.format))
+		docPrinter.kubernetesSupport = kubernetesSupport
+		docPrinter.deprecationNotice = deprecationNotice
+		printer = docPrinter
+	default:
+		mdPrinter := newReleaseNotesPrinter(cmd.config.repo, from.value)
+		mdPrinter.isPreRelease = cmd.config.preReleaseVersion
+		mdPrinter.printDeprecation = cmd.config.deprecation
+		mdPrinter.printKubernetesSupport = cmd.config.addKubernetesVersionSupport
+		mdPrinter.kubernetesSupport = kubernetesSupport
+		printer = mdPrinter
+	}
+
+	var lister PRLister
+	switch resolvedLister {
+	case "gh":
+		lister = newGithubFromToPRLister(cmd.config.repo, from, to, cmd.config.branch)
+	case "git+api":
+		lister = newGitAPIPRLister(cmd.config.repo, from, to)
+	}
+
+	if cmd.config.branch != cmd.config.cherryPickSourceBranch {
+		lister = newCherryPickNormalizer(lister, cmd.config.repo, cmd.config.cherryPickSourceBranch, os.Getenv("GITHUB_TOKEN"), from.value)
+	}
 
 	generator := newNotesGenerator(
-		newGithubFromToPRLister(cmd.config.repo, from, to, cmd.config.branch),
-		newPREntryProcessor(cmd.config.prefixAreaLabel),
+		lister,
+		newPREntryProcessor(cmd.config.prefixAreaLabel, taxonomy(cmd.config.taxonomy)),
 		printer,
 	)
 
 	return generator.run()
 }
 
-func ensureInstalledDependencies() error {
-	if !commandExists("gh") {
-		return errors.New("gh GitHub CLI not available. GitHub CLI is required to be present in the PATH. Refer to https://cli.github.com/ for installation")
+// resolveLister turns the --lister flag into a concrete "gh" or "git+api"
+// choice, auto-detecting based on whether the gh CLI is installed.
+func resolveLister(lister string) (string, error) {
+	switch lister {
+	case "gh":
+		if !commandExists("gh") {
+			return "", errors.New("gh GitHub CLI not available. GitHub CLI is required to be present in the PATH. Refer to https://cli.github.com/ for installation")
+		}
+		return "gh", nil
+	case "git+api":
+		return "git+api", nil
+	case "auto":
+		if commandExists("gh") {
+			return "gh", nil
+		}
+		return "git+api", nil
+	default:
+		return "", errors.Errorf("invalid --lister %q, must be one of gh, git+api, auto", lister)
 	}
-
-	return nil
 }
 
 func commandExists(cmd string) bool {
@@ -148,6 +213,18 @@ func validateConfig(config *notesCmdConfig) error {
 		}
 	}
 
+	switch taxonomy(config.taxonomy) {
+	case taxonomyEmoji, taxonomyAreaLabel, taxonomyBoth:
+	default:
+		return errors.Errorf("invalid --taxonomy %q, must be one of emoji, area-label, both", config.taxonomy)
+	}
+
+	switch outputFormat(config.format) {
+	case outputFormatMarkdown, outputFormatJSON, outputFormatYAML:
+	default:
+		return errors.Errorf("invalid --format %q, must be one of markdown, json, yaml", config.format)
+	}
+
 	return nil
 }
 
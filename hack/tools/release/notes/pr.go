@@ -0,0 +1,154 @@
+//go:build tools
+// +build tools
+
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// PullRequest is the subset of GitHub PR metadata the notes generator needs.
+type PullRequest struct {
+	Number         int
+	Title          string
+	Body           string
+	Author         string
+	Labels         []string
+	MergeCommitSHA string
+	TargetBranch   string
+}
+
+// PRLister lists the pull requests merged in a given range.
+type PRLister interface {
+	listPRs() ([]PullRequest, error)
+}
+
+// githubFromToPRLister lists merged PRs between two refs on a repo by
+// shelling out to the gh CLI, which must already be authenticated.
+type githubFromToPRLister struct {
+	repo   string
+	from   ref
+	to     ref
+	branch string
+}
+
+func newGithubFromToPRLister(repo string, from, to ref, branch string) *githubFromToPRLister {
+	return &githubFromToPRLister{
+		repo:   repo,
+		from:   from,
+		to:     to,
+		branch: branch,
+	}
+}
+
+type ghPullRequest struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	Author struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	MergeCommit struct {
+		Oid string `json:"oid"`
+	} `json:"mergeCommit"`
+	BaseRefName string `json:"baseRefName"`
+}
+
+// listPRs shells out to `gh pr list` scoped to the configured branch, then
+// keeps only the PRs whose merge commit falls within from..to.
+func (l *githubFromToPRLister) listPRs() ([]PullRequest, error) {
+	cmd := exec.Command("gh", "pr", "list",
+		"--repo", l.repo,
+		"--base", l.branch,
+		"--state", "merged",
+		"--limit", "1000",
+		"--json", "number,title,body,author,labels,mergeCommit,baseRefName")
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "failed to list PRs via gh: %s", stderr.String())
+	}
+
+	var raw []ghPullRequest
+	if err := json.Unmarshal(stdout.Bytes(), &raw); err != nil {
+		return nil, errors.Wrap(err, "failed to parse gh pr list output")
+	}
+
+	inRange, err := commitsInRange(l.from.value + ".." + l.to.value)
+	if err != nil {
+		return nil, err
+	}
+
+	prs := make([]PullRequest, 0, len(raw))
+	for _, r := range raw {
+		if !inRange[r.MergeCommit.Oid] {
+			continue
+		}
+
+		labels := make([]string, 0, len(r.Labels))
+		for _, label := range r.Labels {
+			labels = append(labels, label.Name)
+		}
+
+		prs = append(prs, PullRequest{
+			Number:         r.Number,
+			Title:          r.Title,
+			Body:           r.Body,
+			Author:         r.Author.Login,
+			Labels:         labels,
+			MergeCommitSHA: r.MergeCommit.Oid,
+			TargetBranch:   r.BaseRefName,
+		})
+	}
+
+	return prs, nil
+}
+
+// commitsInRange returns the set of commit SHAs reachable in rev (e.g.
+// "v1.5.0..v1.6.0"), used to scope `gh pr list` results - which gh can only
+// filter by base branch and state, not by ref range - down to from..to.
+func commitsInRange(rev string) (map[string]bool, error) {
+	cmd := exec.Command("git", "log", "--pretty=format:%H", rev)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "failed to walk commits %q: %s", rev, stderr.String())
+	}
+
+	shas := make(map[string]bool)
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		if line != "" {
+			shas[line] = true
+		}
+	}
+
+	return shas, nil
+}
@@ -0,0 +1,172 @@
+//go:build tools
+// +build tools
+
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+)
+
+// notesPrinter renders a processed set of PREntry as release notes in
+// whatever output format it implements.
+type notesPrinter interface {
+	print(entries []PREntry) error
+}
+
+// deprecationNoticeText is the templated deprecation warning shared by the
+// Markdown and structured output formats.
+const deprecationNoticeText = "Please review the [Cluster API v1beta1 compared to v1alpha4](https://cluster-api.sigs.k8s.io) for more details."
+
+// sectionOrder is the stable order sections are rendered in, regardless of
+// the order PRs were merged.
+var sectionOrder = []prKind{
+	prKindBreaking,
+	prKindFeature,
+	prKindBugFix,
+	prKindDocumentation,
+	prKindInfra,
+	prKindOther,
+}
+
+// releaseNotesPrinter renders a set of PREntry into the Markdown release
+// notes cluster-api publishes alongside a tag.
+type releaseNotesPrinter struct {
+	repo    string
+	fromRef string
+
+	isPreRelease           bool
+	printDeprecation       bool
+	printKubernetesSupport bool
+	kubernetesSupport      *kubernetesSupportMatrix
+}
+
+func newReleaseNotesPrinter(repo, fromRef string) *releaseNotesPrinter {
+	return &releaseNotesPrinter{
+		repo:    repo,
+		fromRef: fromRef,
+	}
+}
+
+// print renders entries to stdout, grouped into stable Markdown sections
+// with a semver-bump header inferred from the highest-severity kind seen,
+// and a trailing warning for any PR whose title didn't match a known prefix.
+func (p *releaseNotesPrinter) print(entries []PREntry) error {
+	fmt.Println(p.header(entries))
+
+	if p.isPreRelease {
+		fmt.Println(":rotating_light: This is a RELEASE CANDIDATE. Use it only for testing purposes. If you find any bugs, file an issue.")
+	}
+
+	if p.printDeprecation {
+		fmt.Printf("## Deprecation Warning\n\n%s\n\n", deprecationNoticeText)
+	}
+
+	if p.printKubernetesSupport {
+		fmt.Println(p.kubernetesSupportSection())
+	}
+
+	grouped := groupByKind(entries)
+	for _, kind := range sectionOrder {
+		section := grouped[kind]
+		if len(section) == 0 {
+			continue
+		}
+
+		fmt.Printf("## %s\n\n", kind.sectionTitle())
+		for _, entry := range section {
+			fmt.Println(entry.Line)
+		}
+		fmt.Println()
+	}
+
+	if warnings := unknownPrefixWarnings(entries); len(warnings) > 0 {
+		fmt.Println("<!--")
+		fmt.Println("The following PRs have titles that don't match any known prefix. Please ask the authors to fix them:")
+		for _, w := range warnings {
+			fmt.Println(w)
+		}
+		fmt.Println("-->")
+	}
+
+	return nil
+}
+
+func (p *releaseNotesPrinter) header(entries []PREntry) string {
+	return fmt.Sprintf("# Changes since %s\n\n## :bookmark: Release bump: %s", p.fromRef, highestSemverBump(entries))
+}
+
+// kubernetesSupportSection renders the Kubernetes version support matrix.
+// When resolved from an e2e config via --e2e-config it reflects the actual
+// versions the e2e suite ran against; otherwise it falls back to a
+// hand-maintained placeholder that the release manager must fill in.
+func (p *releaseNotesPrinter) kubernetesSupportSection() string {
+	if p.kubernetesSupport == nil {
+		return "## Kubernetes Version Support\n\n* Management Cluster: v1.25.x -> v1.29.x\n* Workload Cluster: v1.23.x -> v1.29.x\n"
+	}
+
+	m := p.kubernetesSupport
+	return fmt.Sprintf("## Kubernetes Version Support\n\n* Management Cluster: %s\n* Workload Cluster: %s -> %s\n* Tested against Kubernetes CI version: %s\n",
+		m.ManagementCluster, m.WorkloadClusterMin, m.WorkloadClusterMax, m.TestedCIVersion)
+}
+
+// groupByKind buckets entries by kind, preserving merge order within a bucket.
+func groupByKind(entries []PREntry) map[prKind][]PREntry {
+	grouped := make(map[prKind][]PREntry)
+	for _, entry := range entries {
+		grouped[entry.Kind] = append(grouped[entry.Kind], entry)
+	}
+
+	return grouped
+}
+
+// highestSemverBump finds the semver bump implied by the single highest
+// severity prKind across all entries.
+func highestSemverBump(entries []PREntry) semverBump {
+	highest := prKindOther
+	for _, entry := range entries {
+		if severityRank(entry.Kind) > severityRank(highest) {
+			highest = entry.Kind
+		}
+	}
+
+	return highest.semverBump()
+}
+
+func severityRank(k prKind) int {
+	for i, candidate := range prKindByIncreasingSeverity {
+		if candidate == k {
+			return i
+		}
+	}
+
+	return 0
+}
+
+// unknownPrefixWarnings lists, in merge order, the PRs whose titles didn't
+// match any known kind prefix.
+func unknownPrefixWarnings(entries []PREntry) []string {
+	var warnings []string
+	for _, entry := range entries {
+		if entry.UnknownPrefix {
+			warnings = append(warnings, fmt.Sprintf("- #%d: %q", entry.PR.Number, entry.PR.Title))
+		}
+	}
+
+	return warnings
+}
@@ -0,0 +1,159 @@
+//go:build tools
+// +build tools
+
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// taxonomy selects which PR title convention is used to classify a PR's kind.
+type taxonomy string
+
+const (
+	taxonomyEmoji     taxonomy = "emoji"
+	taxonomyAreaLabel taxonomy = "area-label"
+	taxonomyBoth      taxonomy = "both"
+)
+
+// areaLabelPrefix is the GitHub label prefix cluster-api uses to tag the
+// area of the codebase a PR touches, e.g. "area/machine".
+const areaLabelPrefix = "area/"
+
+// PREntry is a processed PullRequest, ready to be grouped and rendered by a
+// releaseNotesPrinter.
+type PREntry struct {
+	PR            PullRequest
+	Kind          prKind
+	UnknownPrefix bool
+	Line          string
+}
+
+// PREntryProcessor turns a raw PullRequest into a PREntry, classifying its
+// kind and rendering the Markdown line it will appear as.
+type PREntryProcessor interface {
+	process(pr PullRequest) PREntry
+}
+
+type prEntryProcessor struct {
+	prefixAreaLabel bool
+	taxonomy        taxonomy
+}
+
+// newPREntryProcessor builds a PREntryProcessor. prefixAreaLabel controls
+// whether the rendered line is prefixed with the PR's area label regardless
+// of the taxonomy used to classify its kind.
+func newPREntryProcessor(prefixAreaLabel bool, t taxonomy) *prEntryProcessor {
+	return &prEntryProcessor{
+		prefixAreaLabel: prefixAreaLabel,
+		taxonomy:        t,
+	}
+}
+
+func (p *prEntryProcessor) process(pr PullRequest) PREntry {
+	kind, unknownPrefix := p.classify(pr)
+
+	title := strings.TrimSpace(stripKnownPrefix(pr.Title))
+	if p.prefixAreaLabel {
+		if area := areaLabel(pr.Labels); area != "" {
+			title = fmt.Sprintf("[%s] %s", area, title)
+		}
+	}
+
+	return PREntry{
+		PR:            pr,
+		Kind:          kind,
+		UnknownPrefix: unknownPrefix,
+		Line:          fmt.Sprintf("- %s (#%d, @%s)", title, pr.Number, pr.Author),
+	}
+}
+
+// classify determines a PR's kind according to the configured taxonomy.
+// "unknownPrefix" is true only when the emoji taxonomy participated in the
+// decision and the title didn't carry a recognized prefix, so callers can
+// warn contributors to fix their titles.
+func (p *prEntryProcessor) classify(pr PullRequest) (kind prKind, unknownPrefix bool) {
+	emojiK, hasEmoji := emojiKind(pr.Title)
+	areaK := areaLabelKind(pr.Labels)
+
+	switch p.taxonomy {
+	case taxonomyAreaLabel:
+		return areaK, false
+	case taxonomyBoth:
+		if hasEmoji {
+			return emojiK, false
+		}
+		return areaK, true
+	default: // taxonomyEmoji
+		if hasEmoji {
+			return emojiK, false
+		}
+		return prKindOther, true
+	}
+}
+
+// areaLabelKind maps a documentation/infra-ish area label to a prKind, used
+// as a fallback when a PR title carries no emoji/shortcode prefix.
+//
+// Area labels only distinguish docs and infra/CI work from everything else;
+// cluster-api has no area label convention for signaling breaking changes,
+// features or bugfixes, so every other area (area/machine, area/bootstrap,
+// area/clusterclass, ...) falls into prKindOther here. taxonomyAreaLabel is
+// therefore not a drop-in replacement for taxonomyEmoji's semver-bump
+// signal - see the --taxonomy flag help text.
+func areaLabelKind(labels []string) prKind {
+	for _, label := range labels {
+		switch label {
+		case "area/docs", "area/documentation":
+			return prKindDocumentation
+		case "area/testing", "area/ci", "area/infra":
+			return prKindInfra
+		}
+	}
+
+	return prKindOther
+}
+
+// areaLabel returns the first "area/" label on a PR, stripped of its prefix.
+func areaLabel(labels []string) string {
+	for _, label := range labels {
+		if strings.HasPrefix(label, areaLabelPrefix) {
+			return strings.TrimPrefix(label, areaLabelPrefix)
+		}
+	}
+
+	return ""
+}
+
+// stripKnownPrefix removes a leading emoji/shortcode kind prefix from a PR
+// title so it isn't duplicated once the entry is grouped under its section.
+func stripKnownPrefix(title string) string {
+	trimmed := strings.TrimSpace(title)
+	for _, p := range prefixes {
+		if strings.HasPrefix(trimmed, p.emoji) {
+			return strings.TrimSpace(strings.TrimPrefix(trimmed, p.emoji))
+		}
+		if strings.HasPrefix(trimmed, p.text) {
+			return strings.TrimSpace(strings.TrimPrefix(trimmed, p.text))
+		}
+	}
+
+	return trimmed
+}
@@ -0,0 +1,134 @@
+//go:build tools
+// +build tools
+
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import "testing"
+
+func TestAreaLabelKind(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels []string
+		want   prKind
+	}{
+		{name: "docs", labels: []string{"area/docs"}, want: prKindDocumentation},
+		{name: "documentation", labels: []string{"area/documentation"}, want: prKindDocumentation},
+		{name: "ci", labels: []string{"area/ci"}, want: prKindInfra},
+		{name: "infra", labels: []string{"area/infra"}, want: prKindInfra},
+		{name: "unrecognized area falls back to other", labels: []string{"area/machine"}, want: prKindOther},
+		{name: "no area labels", labels: nil, want: prKindOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := areaLabelKind(tt.labels); got != tt.want {
+				t.Errorf("areaLabelKind(%v) = %v, want %v", tt.labels, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name              string
+		taxonomy          taxonomy
+		pr                PullRequest
+		wantKind          prKind
+		wantUnknownPrefix bool
+	}{
+		{
+			name:     "emoji taxonomy uses the emoji prefix",
+			taxonomy: taxonomyEmoji,
+			pr:       PullRequest{Title: "✨ Add support for foo", Labels: []string{"area/machine"}},
+			wantKind: prKindFeature,
+		},
+		{
+			name:              "emoji taxonomy without a prefix is unknown",
+			taxonomy:          taxonomyEmoji,
+			pr:                PullRequest{Title: "Add support for foo", Labels: []string{"area/docs"}},
+			wantKind:          prKindOther,
+			wantUnknownPrefix: true,
+		},
+		{
+			name:     "area-label taxonomy ignores the emoji prefix",
+			taxonomy: taxonomyAreaLabel,
+			pr:       PullRequest{Title: "✨ Add support for foo", Labels: []string{"area/docs"}},
+			wantKind: prKindDocumentation,
+		},
+		{
+			name:     "both taxonomy prefers emoji when present",
+			taxonomy: taxonomyBoth,
+			pr:       PullRequest{Title: "🐛 Fix the foo bug", Labels: []string{"area/docs"}},
+			wantKind: prKindBugFix,
+		},
+		{
+			name:              "both taxonomy falls back to area label",
+			taxonomy:          taxonomyBoth,
+			pr:                PullRequest{Title: "Fix the foo bug", Labels: []string{"area/docs"}},
+			wantKind:          prKindDocumentation,
+			wantUnknownPrefix: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &prEntryProcessor{taxonomy: tt.taxonomy}
+			kind, unknownPrefix := p.classify(tt.pr)
+			if kind != tt.wantKind {
+				t.Errorf("classify() kind = %v, want %v", kind, tt.wantKind)
+			}
+			if unknownPrefix != tt.wantUnknownPrefix {
+				t.Errorf("classify() unknownPrefix = %v, want %v", unknownPrefix, tt.wantUnknownPrefix)
+			}
+		})
+	}
+}
+
+func TestProcess(t *testing.T) {
+	p := newPREntryProcessor(true, taxonomyEmoji)
+	entry := p.process(PullRequest{Number: 42, Author: "someone", Title: "✨ Add support for foo", Labels: []string{"area/machine"}})
+
+	wantLine := "- [machine] Add support for foo (#42, @someone)"
+	if entry.Line != wantLine {
+		t.Errorf("process().Line = %q, want %q", entry.Line, wantLine)
+	}
+	if entry.Kind != prKindFeature {
+		t.Errorf("process().Kind = %v, want %v", entry.Kind, prKindFeature)
+	}
+	if entry.UnknownPrefix {
+		t.Error("process().UnknownPrefix = true, want false")
+	}
+}
+
+func TestStripKnownPrefix(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{title: "⚠️ Drop support for v1alpha3", want: "Drop support for v1alpha3"},
+		{title: ":bug: Fix the foo bug", want: "Fix the foo bug"},
+		{title: "Fix the foo bug", want: "Fix the foo bug"},
+	}
+
+	for _, tt := range tests {
+		if got := stripKnownPrefix(tt.title); got != tt.want {
+			t.Errorf("stripKnownPrefix(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
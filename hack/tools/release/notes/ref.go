@@ -0,0 +1,68 @@
+//go:build tools
+// +build tools
+
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// refKind identifies whether a ref points at a branch, a tag or a raw commit.
+type refKind int
+
+const (
+	refKindBranch refKind = iota
+	refKindTag
+	refKindCommit
+)
+
+// ref is a parsed --from/--to value, e.g. "heads/main" or "tags/v1.6.0".
+type ref struct {
+	kind  refKind
+	value string
+}
+
+// validateRef checks that a ref string is either empty or formatted as
+// heads/<branch name> or tags/<tag name>; a bare commit SHA is rejected.
+func validateRef(value string) error {
+	if value == "" {
+		return nil
+	}
+
+	if strings.HasPrefix(value, "heads/") || strings.HasPrefix(value, "tags/") {
+		return nil
+	}
+
+	return errors.Errorf("invalid ref %q, must be formatted as heads/<branch name> or tags/<tag name>", value)
+}
+
+// parseRef splits a ref string into its kind and bare value, e.g.
+// "heads/main" -> {refKindBranch, "main"}.
+func parseRef(value string) ref {
+	switch {
+	case strings.HasPrefix(value, "heads/"):
+		return ref{kind: refKindBranch, value: strings.TrimPrefix(value, "heads/")}
+	case strings.HasPrefix(value, "tags/"):
+		return ref{kind: refKindTag, value: strings.TrimPrefix(value, "tags/")}
+	default:
+		return ref{kind: refKindCommit, value: value}
+	}
+}
@@ -0,0 +1,65 @@
+//go:build tools
+// +build tools
+
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import "testing"
+
+func TestValidateRef(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "empty is valid", value: ""},
+		{name: "heads prefix is valid", value: "heads/main"},
+		{name: "tags prefix is valid", value: "tags/v1.6.0"},
+		{name: "bare commit is invalid", value: "abc1234", wantErr: true},
+		{name: "bare branch name is invalid", value: "main", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRef(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateRef(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseRef(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  ref
+	}{
+		{name: "heads", value: "heads/main", want: ref{kind: refKindBranch, value: "main"}},
+		{name: "tags", value: "tags/v1.6.0", want: ref{kind: refKindTag, value: "v1.6.0"}},
+		{name: "commit", value: "abc1234", want: ref{kind: refKindCommit, value: "abc1234"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRef(tt.value); got != tt.want {
+				t.Errorf("parseRef(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+//go:build tools
+// +build tools
+
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// outputFormat selects the shape a notesPrinter renders entries in.
+type outputFormat string
+
+const (
+	outputFormatMarkdown outputFormat = "markdown"
+	outputFormatJSON     outputFormat = "json"
+	outputFormatYAML     outputFormat = "yaml"
+)
+
+// notesDocument is the structured, renderer-agnostic representation of a set
+// of release notes. Only the JSON and YAML formats use it: releaseNotesPrinter
+// renders Markdown from the PREntry values directly and does not build a
+// notesDocument. The JSON and YAML formats serialize it so downstream tooling (changelog
+// aggregators, provider release automation, website generators) doesn't
+// have to regex-parse Markdown.
+type notesDocument struct {
+	Repo              string                   `json:"repo" yaml:"repo"`
+	FromRef           string                   `json:"fromRef" yaml:"fromRef"`
+	ToRef             string                   `json:"toRef" yaml:"toRef"`
+	ReleaseTag        string                   `json:"releaseTag,omitempty" yaml:"releaseTag,omitempty"`
+	SemverBump        semverBump               `json:"semverBump" yaml:"semverBump"`
+	KubernetesSupport *kubernetesSupportMatrix `json:"kubernetesSupport,omitempty" yaml:"kubernetesSupport,omitempty"`
+	DeprecationNotice string                   `json:"deprecationNotice,omitempty" yaml:"deprecationNotice,omitempty"`
+	Entries           []notesDocumentEntry     `json:"entries" yaml:"entries"`
+}
+
+type notesDocumentEntry struct {
+	Number         int      `json:"number" yaml:"number"`
+	Author         string   `json:"author" yaml:"author"`
+	Title          string   `json:"title" yaml:"title"`
+	AreaLabels     []string `json:"areaLabels,omitempty" yaml:"areaLabels,omitempty"`
+	Kind           string   `json:"kind" yaml:"kind"`
+	Breaking       bool     `json:"breaking" yaml:"breaking"`
+	TargetBranch   string   `json:"targetBranch,omitempty" yaml:"targetBranch,omitempty"`
+	MergeCommitSHA string   `json:"mergeCommitSha,omitempty" yaml:"mergeCommitSha,omitempty"`
+}
+
+// structuredPrinter renders entries as a notesDocument, marshaled to either
+// JSON or YAML.
+type structuredPrinter struct {
+	repo              string
+	fromRef           string
+	toRef             string
+	releaseTag        string
+	format            outputFormat
+	kubernetesSupport *kubernetesSupportMatrix
+	deprecationNotice string
+}
+
+func newStructuredPrinter(repo, fromRef, toRef, releaseTag string, format outputFormat) *structuredPrinter {
+	return &structuredPrinter{
+		repo:       repo,
+		fromRef:    fromRef,
+		toRef:      toRef,
+		releaseTag: releaseTag,
+		format:     format,
+	}
+}
+
+func (p *structuredPrinter) print(entries []PREntry) error {
+	doc := p.document(entries)
+
+	switch p.format {
+	case outputFormatYAML:
+		out, err := yaml.Marshal(doc)
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(out))
+	default: // outputFormatJSON
+		out, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+	}
+
+	return nil
+}
+
+func (p *structuredPrinter) document(entries []PREntry) notesDocument {
+	docEntries := make([]notesDocumentEntry, 0, len(entries))
+	for _, entry := range entries {
+		docEntries = append(docEntries, notesDocumentEntry{
+			Number:         entry.PR.Number,
+			Author:         entry.PR.Author,
+			Title:          entry.PR.Title,
+			AreaLabels:     areaLabels(entry.PR.Labels),
+			Kind:           entry.Kind.sectionTitle(),
+			Breaking:       entry.Kind == prKindBreaking,
+			TargetBranch:   entry.PR.TargetBranch,
+			MergeCommitSHA: entry.PR.MergeCommitSHA,
+		})
+	}
+
+	return notesDocument{
+		Repo:              p.repo,
+		FromRef:           p.fromRef,
+		ToRef:             p.toRef,
+		ReleaseTag:        p.releaseTag,
+		SemverBump:        highestSemverBump(entries),
+		KubernetesSupport: p.kubernetesSupport,
+		DeprecationNotice: p.deprecationNotice,
+		Entries:           docEntries,
+	}
+}
+
+// areaLabels returns every "area/" label on a PR, stripped of its prefix.
+func areaLabels(labels []string) []string {
+	var areas []string
+	for _, label := range labels {
+		if strings.HasPrefix(label, areaLabelPrefix) {
+			areas = append(areas, strings.TrimPrefix(label, areaLabelPrefix))
+		}
+	}
+
+	return areas
+}